@@ -0,0 +1,201 @@
+/*
+ *
+ * Copyright SecureKey Technologies Inc. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * /
+ *
+ */
+
+package connectionstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+const (
+	// nameSpace is the storage store name connection records are persisted under.
+	nameSpace = "didexchange"
+
+	connIDKeyPrefix    = "conn"
+	connStateKeyPrefix = "connstate"
+
+	stateIDEmptyErr = "stateID can't be empty"
+
+	endKeySuffix = "\xff"
+)
+
+// ConnectionRecord contains information about the connection between the two agents.
+type ConnectionRecord struct {
+	ConnectionID    string
+	State           string
+	ThreadID        string
+	ParentThreadID  string
+	TheirLabel      string
+	TheirDID        string
+	MyDID           string
+	ServiceEndPoint string
+	RecipientKeys   []string
+	RoutingKeys     []string
+	InvitationID    string
+	InvitationDID   string
+	Implicit        bool
+	Namespace       string
+}
+
+// provider is the set of dependencies a ConnectionLookup needs from its caller.
+type provider interface {
+	TransientStorageProvider() storage.Provider
+	StorageProvider() storage.Provider
+}
+
+// ConnectionLookup provides read-only access to connection records, regardless of whether they
+// currently live in the persistent store or the transient one.
+type ConnectionLookup struct {
+	store          storage.Store
+	transientStore storage.Store
+}
+
+// NewConnectionLookup returns a new ConnectionLookup backed by p's storage providers.
+func NewConnectionLookup(p provider) (*ConnectionLookup, error) {
+	store, err := p.StorageProvider().OpenStore(nameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+
+	transientStore, err := p.TransientStorageProvider().OpenStore(nameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transient store: %w", err)
+	}
+
+	return &ConnectionLookup{store: store, transientStore: transientStore}, nil
+}
+
+// Store returns the underlying persistent store.
+func (c *ConnectionLookup) Store() storage.Store {
+	return c.store
+}
+
+// TransientStore returns the underlying transient store.
+func (c *ConnectionLookup) TransientStore() storage.Store {
+	return c.transientStore
+}
+
+// GetConnectionRecord returns the connection record for the given connection id, checking the
+// persistent store first and falling back to the transient store.
+func (c *ConnectionLookup) GetConnectionRecord(connectionID string) (*ConnectionRecord, error) {
+	key := GetConnectionKeyPrefix()(connectionID)
+
+	recordBytes, err := c.store.Get(key)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			return nil, err
+		}
+
+		recordBytes, err = c.transientStore.Get(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return prepareConnectionRecord(recordBytes)
+}
+
+// GetConnectionRecordAtState returns the connection record persisted for connectionID while it
+// was in the given state. State records only ever live in the transient store - they represent
+// in-flight protocol state, not a durable connection.
+func (c *ConnectionLookup) GetConnectionRecordAtState(connectionID, stateID string) (*ConnectionRecord, error) {
+	if stateID == "" {
+		return nil, errors.New(stateIDEmptyErr)
+	}
+
+	recordBytes, err := c.transientStore.Get(GetConnectionStateKeyPrefix()(connectionID, stateID))
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareConnectionRecord(recordBytes)
+}
+
+// GetConnectionRecordByNSThreadID returns the connection record associated with the given
+// namespaced thread id. The mapping from thread id to connection id, like connection state, is
+// only ever kept in the transient store.
+func (c *ConnectionLookup) GetConnectionRecordByNSThreadID(nsThreadID string) (*ConnectionRecord, error) {
+	connectionIDBytes, err := c.transientStore.Get(nsThreadID)
+	if err != nil {
+		return nil, fmt.Errorf("get connectionID by NSThreadID: %w", err)
+	}
+
+	recordBytes, err := c.transientStore.Get(GetConnectionKeyPrefix()(string(connectionIDBytes)))
+	if err != nil {
+		return nil, fmt.Errorf("get connection record by NSThreadID: %w", err)
+	}
+
+	return prepareConnectionRecord(recordBytes)
+}
+
+// QueryConnectionRecords returns every connection record in both the persistent and transient
+// stores. A connection id present in both is only returned once, with the persistent copy taking
+// precedence.
+func (c *ConnectionLookup) QueryConnectionRecords() ([]*ConnectionRecord, error) {
+	records := make(map[string]*ConnectionRecord)
+
+	for _, store := range []storage.Store{c.transientStore, c.store} {
+		if err := collectConnectionRecords(store, records); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*ConnectionRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, record)
+	}
+
+	return result, nil
+}
+
+func collectConnectionRecords(store storage.Store, out map[string]*ConnectionRecord) error {
+	itr := store.Iterator(connIDKeyPrefix+"_", connIDKeyPrefix+"_"+endKeySuffix)
+	defer itr.Release()
+
+	for itr.Next() {
+		record, err := prepareConnectionRecord(itr.Value())
+		if err != nil {
+			return fmt.Errorf("query connection records: %w", err)
+		}
+
+		out[string(itr.Key())] = record
+	}
+
+	return itr.Error()
+}
+
+// prepareConnectionRecord unmarshals a persisted connection record.
+func prepareConnectionRecord(recordBytes []byte) (*ConnectionRecord, error) {
+	record := &ConnectionRecord{}
+
+	if err := json.Unmarshal(recordBytes, record); err != nil {
+		return nil, fmt.Errorf("prepare connection record: %w", err)
+	}
+
+	return record, nil
+}
+
+// GetConnectionKeyPrefix returns a function that builds the storage key for a connection id.
+func GetConnectionKeyPrefix() func(connectionID string) string {
+	return func(connectionID string) string {
+		return fmt.Sprintf("%s_%s", connIDKeyPrefix, connectionID)
+	}
+}
+
+// GetConnectionStateKeyPrefix returns a function that builds the storage key for a connection id
+// at a given state.
+func GetConnectionStateKeyPrefix() func(connectionID, stateID string) string {
+	return func(connectionID, stateID string) string {
+		return fmt.Sprintf("%s_%s_%s", connStateKeyPrefix, connectionID, stateID)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ *
+ * Copyright SecureKey Technologies Inc. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * /
+ *
+ */
+
+package connectionstore
+
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// ErrReadOnly is returned by a read-only store shim for any attempted write.
+var ErrReadOnly = errors.New("connection store is read-only")
+
+// NewReadOnlyConnectionLookup returns a ConnectionLookup whose persistent and transient stores
+// both reject writes with ErrReadOnly, while continuing to serve Get and Iterator as usual. It's
+// meant for callers that only ever need to inspect connection state - forensic tooling, dry-run
+// migrations, or an "observer" node - and must never risk mutating it.
+func NewReadOnlyConnectionLookup(p provider) (*ConnectionLookup, error) {
+	return NewConnectionLookup(&readOnlyProvider{p: p})
+}
+
+// readOnlyProvider wraps a provider so that every store it opens is read-only.
+type readOnlyProvider struct {
+	p provider
+}
+
+func (r *readOnlyProvider) TransientStorageProvider() storage.Provider {
+	return &readOnlyStoreProvider{wrapped: r.p.TransientStorageProvider()}
+}
+
+func (r *readOnlyProvider) StorageProvider() storage.Provider {
+	return &readOnlyStoreProvider{wrapped: r.p.StorageProvider()}
+}
+
+// readOnlyStoreProvider opens stores wrapped in readOnlyStore.
+type readOnlyStoreProvider struct {
+	wrapped storage.Provider
+}
+
+func (r *readOnlyStoreProvider) OpenStore(name string) (storage.Store, error) {
+	store, err := r.wrapped.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readOnlyStore{wrapped: store}, nil
+}
+
+// readOnlyStore serves Get and Iterator from the wrapped store, but turns every write into
+// ErrReadOnly so nothing it sits in front of ever reaches disk.
+type readOnlyStore struct {
+	wrapped storage.Store
+}
+
+func (r *readOnlyStore) Put(string, []byte) error {
+	return ErrReadOnly
+}
+
+func (r *readOnlyStore) Delete(string) error {
+	return ErrReadOnly
+}
+
+func (r *readOnlyStore) Get(k string) ([]byte, error) {
+	return r.wrapped.Get(k)
+}
+
+func (r *readOnlyStore) Iterator(startKey, endKey string) storage.StoreIterator {
+	return r.wrapped.Iterator(startKey, endKey)
+}
@@ -355,6 +355,76 @@ func TestConnectionRecorder_QueryConnectionRecord(t *testing.T) {
 	})
 }
 
+func TestNewReadOnlyConnectionLookup(t *testing.T) {
+	t.Run("create new read-only connection lookup", func(t *testing.T) {
+		store, err := NewReadOnlyConnectionLookup(&mockProvider{})
+		require.NoError(t, err)
+		require.NotNil(t, store)
+		require.NotNil(t, store.TransientStore())
+		require.NotNil(t, store.Store())
+	})
+
+	t.Run("no write escapes to the persistent or transient store", func(t *testing.T) {
+		store, err := NewReadOnlyConnectionLookup(&mockProvider{})
+		require.NoError(t, err)
+		require.NotNil(t, store)
+
+		err = store.Store().Put("abc", []byte("def"))
+		require.Equal(t, ErrReadOnly, err)
+
+		err = store.Store().Delete("abc")
+		require.Equal(t, ErrReadOnly, err)
+
+		err = store.TransientStore().Put("abc", []byte("def"))
+		require.Equal(t, ErrReadOnly, err)
+
+		err = store.TransientStore().Delete("abc")
+		require.Equal(t, ErrReadOnly, err)
+	})
+}
+
+func TestReadOnlyConnectionLookup_GetAndQueryConnectionRecord(t *testing.T) {
+	const noOfItems = 12
+	connectionIDS := make([]string, noOfItems)
+
+	for i := 0; i < noOfItems; i++ {
+		connectionIDS[i] = fmt.Sprintf(connIDFmt, i)
+	}
+
+	underlyingStore := &mockstorage.MockStore{Store: make(map[string][]byte)}
+	underlyingTransientStore := &mockstorage.MockStore{Store: make(map[string][]byte)}
+
+	for _, id := range connectionIDS {
+		connRecBytes, err := json.Marshal(&ConnectionRecord{ConnectionID: id, ThreadID: fmt.Sprintf(threadIDFmt, id)})
+		require.NoError(t, err)
+		err = underlyingStore.Put(GetConnectionKeyPrefix()(id), connRecBytes)
+		require.NoError(t, err)
+	}
+
+	store, err := NewReadOnlyConnectionLookup(&mockProvider{store: underlyingStore, transientStore: underlyingTransientStore})
+	require.NoError(t, err)
+	require.NotNil(t, store)
+
+	for _, connectionID := range connectionIDS {
+		connection, err := store.GetConnectionRecord(connectionID)
+		require.NoError(t, err)
+		require.NotNil(t, connection)
+		require.Equal(t, connectionID, connection.ConnectionID)
+	}
+
+	records, err := store.QueryConnectionRecords()
+	require.NoError(t, err)
+	require.Len(t, records, noOfItems)
+
+	// writes attempted through the lookup's own stores still never land
+	err = store.Store().Put(GetConnectionKeyPrefix()(connectionIDS[0]), []byte("tampered"))
+	require.Equal(t, ErrReadOnly, err)
+
+	connection, err := store.GetConnectionRecord(connectionIDS[0])
+	require.NoError(t, err)
+	require.NotEqual(t, "tampered", string(connection.ConnectionID))
+}
+
 // mockProvider for connection recorder
 type mockProvider struct {
 	transientStoreError error
@@ -387,4 +457,4 @@ func (p *mockProvider) StorageProvider() storage.Provider {
 	}
 
 	return mockstorage.NewMockStoreProvider()
-}
\ No newline at end of file
+}
@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// opType identifies the kind of change a single delta operation makes to a peer DID document.
+type opType string
+
+const (
+	opAddKey           opType = "add-key"
+	opRemoveKey        opType = "remove-key"
+	opAddEndpoint      opType = "add-endpoint"
+	opRemoveEndpoint   opType = "remove-endpoint"
+	opAddController    opType = "add-controller"
+	opRemoveController opType = "remove-controller"
+)
+
+// operation is a single add/remove change carried by a Delta. Only the fields relevant to Type
+// are populated: Key for add-key, KeyID for remove-key and remove-controller, Endpoint for the
+// endpoint operations, and both KeyID and Controller for add-controller.
+type operation struct {
+	Type       opType         `json:"type"`
+	Key        *did.PublicKey `json:"key,omitempty"`
+	KeyID      string         `json:"keyId,omitempty"`
+	Endpoint   string         `json:"endpoint,omitempty"`
+	Controller string         `json:"controller,omitempty"`
+}
+
+// Delta is a single signed fragment in a peer DID's change log. Previous is the hash of the doc
+// state the delta was built against, Change is the json-encoded list of operations to apply on
+// top of it, and JWS is a compact JWS whose payload is Change, signed by one of the
+// authentication keys authorized in that previous state.
+type Delta struct {
+	Previous string `json:"previous"`
+	Change   []byte `json:"change"`
+	JWS      string `json:"jws"`
+}
+
+// newChange json-encodes ops for use as a Delta's Change payload.
+func newChange(ops []operation) ([]byte, error) {
+	if len(ops) == 0 {
+		return nil, errors.New("delta must contain at least one operation")
+	}
+
+	return json.Marshal(ops)
+}
+
+// decodeOperations parses a Delta's Change payload back into the operations it carries.
+func decodeOperations(change []byte) ([]operation, error) {
+	var ops []operation
+
+	if err := json.Unmarshal(change, &ops); err != nil {
+		return nil, fmt.Errorf("decode delta change: %w", err)
+	}
+
+	if len(ops) == 0 {
+		return nil, errors.New("delta must contain at least one operation")
+	}
+
+	return ops, nil
+}
+
+// applyOperations folds ops on top of doc and returns the resulting document. doc is not
+// mutated - a copy carrying the updated state is returned.
+func applyOperations(doc *did.Doc, ops []operation) (*did.Doc, error) {
+	next := &did.Doc{
+		Context:        doc.Context,
+		ID:             doc.ID,
+		PublicKey:      append([]did.PublicKey{}, doc.PublicKey...),
+		Authentication: append([]did.VerificationMethod{}, doc.Authentication...),
+		Service:        append([]did.Service{}, doc.Service...),
+		Created:        doc.Created,
+	}
+
+	for _, op := range ops {
+		if err := applyOperation(next, op); err != nil {
+			return nil, err
+		}
+	}
+
+	return next, nil
+}
+
+func applyOperation(doc *did.Doc, op operation) error {
+	switch op.Type {
+	case opAddKey:
+		if op.Key == nil {
+			return errors.New("add-key operation missing key")
+		}
+
+		doc.PublicKey = append(doc.PublicKey, *op.Key)
+		doc.Authentication = append(doc.Authentication, did.VerificationMethod{PublicKey: *op.Key})
+	case opRemoveKey:
+		doc.PublicKey = removeKey(doc.PublicKey, op.KeyID)
+		doc.Authentication = removeAuthentication(doc.Authentication, op.KeyID)
+	case opAddEndpoint:
+		if op.Endpoint == "" {
+			return errors.New("add-endpoint operation missing endpoint")
+		}
+
+		doc.Service = append(doc.Service, did.Service{ServiceEndpoint: op.Endpoint})
+	case opRemoveEndpoint:
+		doc.Service = removeEndpoint(doc.Service, op.Endpoint)
+	case opAddController:
+		if op.Controller == "" || op.KeyID == "" {
+			return errors.New("add-controller operation missing controller or key id")
+		}
+
+		setController(doc, op.KeyID, op.Controller)
+	case opRemoveController:
+		setController(doc, op.KeyID, "")
+	default:
+		return fmt.Errorf("unsupported delta operation: %s", op.Type)
+	}
+
+	return nil
+}
+
+// setController reassigns the Controller of the key identified by keyID, wherever it appears in
+// doc's public key and authentication lists.
+func setController(doc *did.Doc, keyID, controller string) {
+	for i := range doc.PublicKey {
+		if doc.PublicKey[i].ID == keyID {
+			doc.PublicKey[i].Controller = controller
+		}
+	}
+
+	for i := range doc.Authentication {
+		if doc.Authentication[i].PublicKey.ID == keyID {
+			doc.Authentication[i].PublicKey.Controller = controller
+		}
+	}
+}
+
+func removeKey(keys []did.PublicKey, keyID string) []did.PublicKey {
+	filtered := make([]did.PublicKey, 0, len(keys))
+
+	for _, k := range keys {
+		if k.ID != keyID {
+			filtered = append(filtered, k)
+		}
+	}
+
+	return filtered
+}
+
+func removeAuthentication(auth []did.VerificationMethod, keyID string) []did.VerificationMethod {
+	filtered := make([]did.VerificationMethod, 0, len(auth))
+
+	for _, a := range auth {
+		if a.PublicKey.ID != keyID {
+			filtered = append(filtered, a)
+		}
+	}
+
+	return filtered
+}
+
+func removeEndpoint(services []did.Service, endpoint string) []did.Service {
+	filtered := make([]did.Service, 0, len(services))
+
+	for _, s := range services {
+		if s.ServiceEndpoint != endpoint {
+			filtered = append(filtered, s)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,207 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// memStore is a minimal in-memory storage.Store used only by this package's tests.
+type memStore struct {
+	data map[string][]byte
+}
+
+func (m *memStore) Put(k string, v []byte) error {
+	m.data[k] = v
+	return nil
+}
+
+func (m *memStore) Get(k string) ([]byte, error) {
+	v, ok := m.data[k]
+	if !ok {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return v, nil
+}
+
+// memProvider is a minimal in-memory storage.Provider used only by this package's tests.
+type memProvider struct {
+	store *memStore
+}
+
+func newMemProvider() *memProvider {
+	return &memProvider{store: &memStore{data: map[string][]byte{}}}
+}
+
+func (p *memProvider) OpenStore(string) (storage.Store, error) {
+	return p.store, nil
+}
+
+func signDelta(t *testing.T, priv ed25519.PrivateKey, kid string, change []byte) string {
+	header, err := json.Marshal(&jwsHeader{KeyID: kid})
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(change)
+	sig := ed25519.Sign(priv, []byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func genesisDocWithEdKey(t *testing.T) (*did.Doc, ed25519.PrivateKey) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	key := did.PublicKey{
+		ID:    "#key-1",
+		Type:  "Ed25519VerificationKey2018",
+		Value: pub,
+	}
+
+	return &did.Doc{
+		Context:        []string{"https://w3id.org/did/v1"},
+		PublicKey:      []did.PublicKey{key},
+		Authentication: []did.VerificationMethod{{PublicKey: key}},
+		Created:        &time.Time{},
+	}, priv
+}
+
+func TestDIDStore_CreateAndApply(t *testing.T) {
+	genesis, priv := genesisDocWithEdKey(t)
+
+	store, err := NewDIDStore(newMemProvider())
+	require.NoError(t, err)
+
+	id, err := store.Create(genesis)
+	require.NoError(t, err)
+	require.Contains(t, id, "did:peer:11")
+
+	tip, err := store.Resolve(id, "")
+	require.NoError(t, err)
+	require.Len(t, tip.Service, 0)
+
+	ops := []operation{{Type: opAddEndpoint, Endpoint: "https://example.com/endpoint"}}
+	change, err := newChange(ops)
+	require.NoError(t, err)
+
+	history, err := store.History(id)
+	require.NoError(t, err)
+	require.True(t, history.Next())
+
+	genesisHash := history.VersionID()
+
+	delta := &Delta{
+		Previous: genesisHash,
+		Change:   change,
+		JWS:      signDelta(t, priv, "#key-1", change),
+	}
+
+	updated, err := store.Apply(id, delta)
+	require.NoError(t, err)
+	require.Len(t, updated.Service, 1)
+	require.Equal(t, "https://example.com/endpoint", updated.Service[0].ServiceEndpoint)
+
+	history, err = store.History(id)
+	require.NoError(t, err)
+
+	count := 0
+	lastVersionID := ""
+
+	for history.Next() {
+		count++
+		lastVersionID = history.VersionID()
+	}
+
+	require.Equal(t, 2, count)
+	require.NotEqual(t, genesisHash, lastVersionID, "an endpoint-only change must not collide with the genesis version id")
+
+	resolved, err := store.ResolveAtTime(id, now())
+	require.NoError(t, err)
+	require.Len(t, resolved.Service, 1)
+
+	byGenesisID, err := store.Resolve(id, genesisHash)
+	require.NoError(t, err)
+	require.Len(t, byGenesisID.Service, 0)
+
+	byLatestID, err := store.Resolve(id, lastVersionID)
+	require.NoError(t, err)
+	require.Len(t, byLatestID.Service, 1)
+}
+
+func TestDIDStore_ApplyRejectsStalePrevious(t *testing.T) {
+	genesis, priv := genesisDocWithEdKey(t)
+
+	store, err := NewDIDStore(newMemProvider())
+	require.NoError(t, err)
+
+	id, err := store.Create(genesis)
+	require.NoError(t, err)
+
+	change, err := newChange([]operation{{Type: opAddEndpoint, Endpoint: "https://example.com/endpoint"}})
+	require.NoError(t, err)
+
+	delta := &Delta{Previous: "not-the-tip", Change: change, JWS: signDelta(t, priv, "#key-1", change)}
+
+	_, err = store.Apply(id, delta)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not extend the current tip")
+}
+
+func TestDIDStore_ApplyRejectsUnauthorizedSigner(t *testing.T) {
+	genesis, _ := genesisDocWithEdKey(t)
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	store, err := NewDIDStore(newMemProvider())
+	require.NoError(t, err)
+
+	id, err := store.Create(genesis)
+	require.NoError(t, err)
+
+	history, err := store.History(id)
+	require.NoError(t, err)
+	require.True(t, history.Next())
+
+	change, err := newChange([]operation{{Type: opAddEndpoint, Endpoint: "https://example.com/endpoint"}})
+	require.NoError(t, err)
+
+	delta := &Delta{
+		Previous: history.VersionID(),
+		Change:   change,
+		JWS:      signDelta(t, otherPriv, "#key-1", change),
+	}
+
+	_, err = store.Apply(id, delta)
+	require.Error(t, err)
+}
+
+func TestDecodeOperations(t *testing.T) {
+	_, err := decodeOperations([]byte(`[]`))
+	require.Error(t, err)
+
+	_, err = newChange(nil)
+	require.Error(t, err)
+
+	ops, err := decodeOperations([]byte(`[{"type":"add-endpoint","endpoint":"https://example.com"}]`))
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+}
+
+func TestApplyOperation_Unsupported(t *testing.T) {
+	doc := &did.Doc{}
+	err := applyOperation(doc, operation{Type: "unknown"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported delta operation")
+}
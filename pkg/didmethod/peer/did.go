@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package peer implements the did:peer method (https://identity.foundation/peer-did-method-spec/),
+// including genesis document creation/validation and the delta-based evolution of a peer DID's
+// state over time.
+package peer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// didMethodPrefix is the prefix of every genesis did:peer:11 identifier.
+const didMethodPrefix = "did:peer:11-"
+
+// didRegex matches a genesis did:peer:11 identifier: the method-specific id is the lowercase
+// hex-encoded sha256 hash of the genesis document.
+var didRegex = regexp.MustCompile(`^did:peer:11-[0-9a-f]{64}$`)
+
+// newDid creates the genesis did:peer:11 identifier for doc by hashing its authorized state.
+// The genesis version must already carry the public keys and authentication methods that will
+// be authorized to sign future deltas.
+func newDid(doc *did.Doc) (string, error) {
+	if len(doc.PublicKey) == 0 || len(doc.Authentication) == 0 {
+		return "", errors.New("the genesis version must include public keys and authentication")
+	}
+
+	docBytes, err := genesisBytes(doc)
+	if err != nil {
+		return "", fmt.Errorf("marshal genesis doc: %w", err)
+	}
+
+	hash, err := computeHash(docBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return didMethodPrefix + string(hash), nil
+}
+
+// validateDID confirms that doc.ID follows the did:peer:11 format and that its method-specific
+// id is in fact the hash of the genesis state carried by doc.
+func validateDID(doc *did.Doc) error {
+	if !didRegex.MatchString(doc.ID) {
+		return errors.New("did doesnt follow matching regex")
+	}
+
+	docBytes, err := genesisBytes(doc)
+	if err != nil {
+		return fmt.Errorf("marshal genesis doc: %w", err)
+	}
+
+	hash, err := computeHash(docBytes)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimPrefix(doc.ID, didMethodPrefix) != string(hash) {
+		return errors.New("hash of the doc doesnt match the computed hash")
+	}
+
+	return nil
+}
+
+// genesisBytes returns the canonical bytes that are hashed to derive/verify a did:peer:11
+// identifier. Only the genesis state is included - the ID itself is never part of its own hash.
+func genesisBytes(doc *did.Doc) ([]byte, error) {
+	return json.Marshal(&did.Doc{
+		Context:        doc.Context,
+		PublicKey:      doc.PublicKey,
+		Authentication: doc.Authentication,
+		Created:        doc.Created,
+	})
+}
+
+// computeHash returns the lowercase hex-encoded sha256 hash of data.
+func computeHash(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty bytes")
+	}
+
+	hash := sha256.Sum256(data)
+
+	return []byte(hex.EncodeToString(hash[:])), nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// jwsHeader is the subset of a JWS protected header this package relies on: the id of
+// the authentication key the delta was signed with.
+type jwsHeader struct {
+	KeyID string `json:"kid"`
+}
+
+// verifyDelta checks that delta.JWS is a valid compact JWS whose payload equals delta.Change,
+// signed by one of the authentication keys authorized in doc.
+func verifyDelta(doc *did.Doc, delta *Delta) error {
+	parts := strings.Split(delta.JWS, ".")
+	if len(parts) != 3 {
+		return errors.New("invalid jws: expected three dot-separated parts")
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("decode jws header: %w", err)
+	}
+
+	var header jwsHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return fmt.Errorf("unmarshal jws header: %w", err)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("decode jws payload: %w", err)
+	}
+
+	if string(payload) != string(delta.Change) {
+		return errors.New("jws payload does not match delta change")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("decode jws signature: %w", err)
+	}
+
+	key := authorizedKey(doc, header.KeyID)
+	if key == nil {
+		return fmt.Errorf("key %q is not authorized to sign deltas for this doc", header.KeyID)
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+
+	return verifySignature(key, []byte(signingInput), sig)
+}
+
+// authorizedKey returns the authentication key with the given id, or nil if it isn't authorized
+// in doc.
+func authorizedKey(doc *did.Doc, keyID string) *did.PublicKey {
+	for i := range doc.Authentication {
+		if doc.Authentication[i].PublicKey.ID == keyID {
+			return &doc.Authentication[i].PublicKey
+		}
+	}
+
+	return nil
+}
+
+// verifySignature checks sig over signingInput using key, dispatching on the key's declared type.
+func verifySignature(key *did.PublicKey, signingInput, sig []byte) error {
+	switch key.Type {
+	case "Ed25519VerificationKey2018":
+		if len(key.Value) != ed25519.PublicKeySize {
+			return errors.New("invalid ed25519 public key length")
+		}
+
+		if !ed25519.Verify(key.Value, signingInput, sig) {
+			return errors.New("invalid delta signature")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported verification key type: %s", key.Type)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/storage"
+)
+
+// logStoreName is the underlying storage.Provider store that backs every peer DID's change log.
+const logStoreName = "peerdidlog"
+
+// version is a single entry in a peer DID's change log: the genesis doc folded with every delta
+// applied up to and including this one.
+type version struct {
+	ID        string    `json:"id"`
+	Doc       *did.Doc  `json:"doc"`
+	Delta     *Delta    `json:"delta,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// didLog is the append-only log of a single peer DID's versions, persisted as one record.
+type didLog struct {
+	Versions []version `json:"versions"`
+}
+
+// DIDStore manages the append-only logs of peer DID change history, persisting them through a
+// storage.Provider and folding genesis doc + deltas into resolvable document revisions.
+type DIDStore struct {
+	store storage.Store
+}
+
+// NewDIDStore opens the peer DID log store on provider.
+func NewDIDStore(provider storage.Provider) (*DIDStore, error) {
+	store, err := provider.OpenStore(logStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open peer did log store: %w", err)
+	}
+
+	return &DIDStore{store: store}, nil
+}
+
+// Create computes the genesis did:peer:11 identifier for doc, seeds its change log with that
+// genesis version and returns the resulting DID.
+func (s *DIDStore) Create(doc *did.Doc) (string, error) {
+	id, err := newDid(doc)
+	if err != nil {
+		return "", err
+	}
+
+	genesis := &did.Doc{
+		Context:        doc.Context,
+		ID:             id,
+		PublicKey:      doc.PublicKey,
+		Authentication: doc.Authentication,
+		Service:        doc.Service,
+		Created:        doc.Created,
+	}
+
+	genesisDocBytes, err := versionBytes(genesis)
+	if err != nil {
+		return "", fmt.Errorf("marshal genesis doc: %w", err)
+	}
+
+	tipHash, err := computeHash(genesisDocBytes)
+	if err != nil {
+		return "", err
+	}
+
+	log := &didLog{Versions: []version{{ID: string(tipHash), Doc: genesis, Timestamp: now()}}}
+
+	if err := s.putLog(id, log); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Apply verifies delta against the current tip of did's log, folds it in, appends the result to
+// the log under a version id that is the hash of its own content, and returns the new document
+// state. The returned document does not carry that version id; it is available through History.
+func (s *DIDStore) Apply(id string, delta *Delta) (*did.Doc, error) {
+	log, err := s.getLog(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tip := log.Versions[len(log.Versions)-1]
+
+	if delta.Previous != tip.ID {
+		return nil, errors.New("delta does not extend the current tip")
+	}
+
+	if err := verifyDelta(tip.Doc, delta); err != nil {
+		return nil, err
+	}
+
+	ops, err := decodeOperations(delta.Change)
+	if err != nil {
+		return nil, err
+	}
+
+	newDoc, err := applyOperations(tip.Doc, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	newDocBytes, err := versionBytes(newDoc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal doc: %w", err)
+	}
+
+	newHash, err := computeHash(newDocBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	newVersion := version{ID: string(newHash), Doc: newDoc, Delta: delta, Timestamp: now()}
+	log.Versions = append(log.Versions, newVersion)
+
+	if err := s.putLog(id, log); err != nil {
+		return nil, err
+	}
+
+	return newVersion.Doc, nil
+}
+
+// History returns every version in did's change log, oldest first.
+func (s *DIDStore) History(id string) (*HistoryIterator, error) {
+	log, err := s.getLog(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HistoryIterator{versions: log.Versions, index: -1}, nil
+}
+
+// Resolve returns the document for did at versionID, or its current tip if versionID is empty.
+func (s *DIDStore) Resolve(id, versionID string) (*did.Doc, error) {
+	log, err := s.getLog(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if versionID == "" {
+		return log.Versions[len(log.Versions)-1].Doc, nil
+	}
+
+	for _, v := range log.Versions {
+		if v.ID == versionID {
+			return v.Doc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("version %q not found for %s", versionID, id)
+}
+
+// ResolveAtTime returns the document for did as it stood at t: the last version whose timestamp
+// is not after t.
+func (s *DIDStore) ResolveAtTime(id string, t time.Time) (*did.Doc, error) {
+	log, err := s.getLog(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *did.Doc
+
+	for _, v := range log.Versions {
+		if v.Timestamp.After(t) {
+			break
+		}
+
+		found = v.Doc
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no version of %s exists at or before %s", id, t)
+	}
+
+	return found, nil
+}
+
+// HistoryIterator walks a peer DID's change log oldest-version-first.
+type HistoryIterator struct {
+	versions []version
+	index    int
+}
+
+// Next advances the iterator and reports whether a version is available.
+func (h *HistoryIterator) Next() bool {
+	h.index++
+	return h.index < len(h.versions)
+}
+
+// VersionID returns the current version's identifier.
+func (h *HistoryIterator) VersionID() string {
+	return h.versions[h.index].ID
+}
+
+// Doc returns the current version's resolved document.
+func (h *HistoryIterator) Doc() *did.Doc {
+	return h.versions[h.index].Doc
+}
+
+// Timestamp returns when the current version was applied.
+func (h *HistoryIterator) Timestamp() time.Time {
+	return h.versions[h.index].Timestamp
+}
+
+func (s *DIDStore) getLog(id string) (*didLog, error) {
+	logBytes, err := s.store.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("no change log exists for %s", id)
+		}
+
+		return nil, err
+	}
+
+	log := &didLog{}
+	if err := json.Unmarshal(logBytes, log); err != nil {
+		return nil, fmt.Errorf("unmarshal change log for %s: %w", id, err)
+	}
+
+	return log, nil
+}
+
+func (s *DIDStore) putLog(id string, log *didLog) error {
+	logBytes, err := json.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("marshal change log for %s: %w", id, err)
+	}
+
+	return s.store.Put(id, logBytes)
+}
+
+// versionBytes returns the canonical bytes hashed to derive a version identifier for an evolved
+// peer DID document. Unlike genesisBytes (which hashes only the genesis state to derive the DID
+// itself), this covers every field a delta can touch - including Service - so that two versions
+// with different state never collide on the same hash.
+func versionBytes(doc *did.Doc) ([]byte, error) {
+	return json.Marshal(&did.Doc{
+		Context:        doc.Context,
+		PublicKey:      doc.PublicKey,
+		Authentication: doc.Authentication,
+		Service:        doc.Service,
+		Created:        doc.Created,
+	})
+}
+
+// now is a seam so history timestamps can be stubbed out in tests.
+var now = time.Now